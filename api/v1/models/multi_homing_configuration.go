@@ -9,6 +9,9 @@ package models
 // Editing this file might prove futile when you re-run the swagger generate command
 
 import (
+	"strconv"
+
+	"github.com/go-openapi/errors"
 	"github.com/go-openapi/strfmt"
 	"github.com/go-openapi/swag"
 )
@@ -18,12 +21,50 @@ import (
 // swagger:model MultiHomingConfiguration
 type MultiHomingConfiguration struct {
 
+	// Health status of each configured multi-homing device, populated by a
+	// background link-state watcher
+	DeviceStatus []*MultiHomingDeviceStatus `json:"device-status"`
+
 	// List of devices used in multi-homing mode
 	Devices []string `json:"devices"`
 }
 
 // Validate validates this multi homing configuration
 func (m *MultiHomingConfiguration) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateDeviceStatus(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *MultiHomingConfiguration) validateDeviceStatus(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.DeviceStatus) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.DeviceStatus); i++ {
+		if swag.IsZero(m.DeviceStatus[i]) { // not required
+			continue
+		}
+
+		if m.DeviceStatus[i] != nil {
+			if err := m.DeviceStatus[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("device-status" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
 	return nil
 }
 
@@ -43,4 +84,4 @@ func (m *MultiHomingConfiguration) UnmarshalBinary(b []byte) error {
 	}
 	*m = res
 	return nil
-}
\ No newline at end of file
+}
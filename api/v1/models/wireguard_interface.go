@@ -23,6 +23,10 @@ import (
 // swagger:model WireguardInterface
 type WireguardInterface struct {
 
+	// Number of peers on this interface that have not completed a handshake.
+	// Only populated when the status request is made with with-traffic=true.
+	HandshakeFailures int64 `json:"handshake-failures,omitempty"`
+
 	// Port on which the Wireguard endpoint is exposed
 	ListenPort int64 `json:"listen-port,omitempty"`
 
@@ -37,6 +41,18 @@ type WireguardInterface struct {
 
 	// Public key of this interface
 	PublicKey string `json:"public-key,omitempty"`
+
+	// Aggregate bytes received across all peers on this interface.
+	// Only populated when the status request is made with with-traffic=true.
+	RxBytes int64 `json:"rx-bytes,omitempty"`
+
+	// Number of peers whose last handshake is older than the rekey threshold (~180s).
+	// Only populated when the status request is made with with-traffic=true.
+	StalePeerCount int64 `json:"stale-peer-count,omitempty"`
+
+	// Aggregate bytes transmitted across all peers on this interface.
+	// Only populated when the status request is made with with-traffic=true.
+	TxBytes int64 `json:"tx-bytes,omitempty"`
 }
 
 // Validate validates this wireguard interface
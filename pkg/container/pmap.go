@@ -62,9 +62,19 @@ type mapNode struct {
 	value       *refValue
 	weight      uint64
 	refCount    int32
+	size        int32
 	left, right *mapNode
 }
 
+// nodeSize returns the number of keys in the subtree rooted at node, or 0
+// for a nil node.
+func nodeSize(node *mapNode) int32 {
+	if node == nil {
+		return 0
+	}
+	return node.size
+}
+
 type refValue struct {
 	refCount int32
 	value    interface{}
@@ -81,9 +91,13 @@ func newNodeWithRef(key, value interface{}, release func(key, value interface{})
 		},
 		refCount: 1,
 		weight:   rand.Uint64(),
+		size:     1,
 	}
 }
 
+// shallowCloneWithRef returns a new node sharing the same key/value as node,
+// but with no children. Callers that attach children are expected to update
+// the clone's size afterwards.
 func (node *mapNode) shallowCloneWithRef() *mapNode {
 	atomic.AddInt32(&node.value.refCount, 1)
 	return &mapNode{
@@ -91,6 +105,7 @@ func (node *mapNode) shallowCloneWithRef() *mapNode {
 		value:    node.value,
 		weight:   node.weight,
 		refCount: 1,
+		size:     1,
 	}
 }
 
@@ -242,6 +257,7 @@ func union(first, second *mapNode, less func(a, b interface{}) bool, overwrite b
 	result.weight = first.weight
 	result.left = union(first.left, left, less, overwrite)
 	result.right = union(first.right, right, less, overwrite)
+	result.size = nodeSize(result.left) + nodeSize(result.right) + 1
 	left.decref()
 	mid.decref()
 	right.decref()
@@ -271,6 +287,7 @@ func split(n *mapNode, key interface{}, less func(a, b interface{}) bool, requir
 		newN := n.shallowCloneWithRef()
 		newN.left = n.left.incref()
 		newN.right = left
+		newN.size = nodeSize(newN.left) + nodeSize(newN.right) + 1
 		return newN, mid, right
 	} else if less(key, n.key) {
 		left, mid, right := split(n.left, key, less, requireMid)
@@ -280,6 +297,7 @@ func split(n *mapNode, key interface{}, less func(a, b interface{}) bool, requir
 		newN := n.shallowCloneWithRef()
 		newN.left = right
 		newN.right = n.right.incref()
+		newN.size = nodeSize(newN.left) + nodeSize(newN.right) + 1
 		return left, mid, newN
 	}
 	mid = n.shallowCloneWithRef()
@@ -316,11 +334,269 @@ func merge(left, right *mapNode) *mapNode {
 		root := left.shallowCloneWithRef()
 		root.left = left.left.incref()
 		root.right = merge(left.right, right)
+		root.size = nodeSize(root.left) + nodeSize(root.right) + 1
 		return root
 	default:
 		root := right.shallowCloneWithRef()
 		root.left = merge(left, right.left)
 		root.right = right.right.incref()
+		root.size = nodeSize(root.left) + nodeSize(root.right) + 1
 		return root
 	}
 }
+
+// Len returns the number of entries in the map.
+func (pm *PMap[K, V]) Len() int {
+	return int(nodeSize(pm.root))
+}
+
+// Min returns the smallest key in the map and its associated value. ok is
+// false if the map is empty.
+func (pm *PMap[K, V]) Min() (k K, v V, ok bool) {
+	node := pm.root
+	if node == nil {
+		return k, v, false
+	}
+	for node.left != nil {
+		node = node.left
+	}
+	return node.key.(K), node.value.value.(V), true
+}
+
+// Max returns the largest key in the map and its associated value. ok is
+// false if the map is empty.
+func (pm *PMap[K, V]) Max() (k K, v V, ok bool) {
+	node := pm.root
+	if node == nil {
+		return k, v, false
+	}
+	for node.right != nil {
+		node = node.right
+	}
+	return node.key.(K), node.value.value.(V), true
+}
+
+// Intersect returns a new map holding the entries whose keys are present in
+// both pm and other, with values taken from pm. It is the caller's
+// responsibility to Destroy the result at a later time.
+func (pm *PMap[K, V]) Intersect(other *PMap[K, V]) *PMap[K, V] {
+	return &PMap[K, V]{root: intersect(pm.root, other.root, pm.less)}
+}
+
+// intersect returns a new tree holding the keys present in both first and
+// second, with values taken from first.
+//
+// intersect(first:-0, second:-0) (result:+1)
+// Intersect borrows both subtrees without affecting their refcount and
+// returns a new reference that the caller is expected to call decref.
+func intersect(first, second *mapNode, less func(a, b interface{}) bool) *mapNode {
+	return intersectPreferFirst(first, second, less, true)
+}
+
+// intersectPreferFirst is intersect's recursive implementation. Rebalancing
+// around the higher-weight node (line below) may swap which argument is
+// "first"; preferFirst is flipped along with it, the same way union flips
+// its overwrite flag, so that whichever side the caller originally passed
+// as first keeps winning on a shared key regardless of how the treap
+// happens to be shaped.
+func intersectPreferFirst(first, second *mapNode, less func(a, b interface{}) bool, preferFirst bool) *mapNode {
+	if first == nil || second == nil {
+		return nil
+	}
+	if first.weight < second.weight {
+		first, second, preferFirst = second, first, !preferFirst
+	}
+
+	left, mid, right := split(second, first.key, less, false)
+	leftResult := intersectPreferFirst(first.left, left, less, preferFirst)
+	rightResult := intersectPreferFirst(first.right, right, less, preferFirst)
+
+	var result *mapNode
+	if mid != nil {
+		winner := first
+		if !preferFirst {
+			winner = mid
+		}
+		result = winner.shallowCloneWithRef()
+		result.weight = first.weight
+		result.left = leftResult
+		result.right = rightResult
+		result.size = nodeSize(result.left) + nodeSize(result.right) + 1
+	} else {
+		result = merge(leftResult, rightResult)
+		leftResult.decref()
+		rightResult.decref()
+	}
+	left.decref()
+	mid.decref()
+	right.decref()
+	return result
+}
+
+// Difference returns a new map holding the entries of pm whose keys are not
+// present in other. It is the caller's responsibility to Destroy the result
+// at a later time.
+func (pm *PMap[K, V]) Difference(other *PMap[K, V]) *PMap[K, V] {
+	return &PMap[K, V]{root: difference(pm.root, other.root, pm.less)}
+}
+
+// difference returns a new tree holding the keys of first that are not
+// present in second.
+//
+// difference(first:-0, second:-0) (result:+1)
+// Difference borrows both subtrees without affecting their refcount and
+// returns a new reference that the caller is expected to call decref.
+func difference(first, second *mapNode, less func(a, b interface{}) bool) *mapNode {
+	if first == nil {
+		return nil
+	}
+	if second == nil {
+		return first.incref()
+	}
+
+	left, mid, right := split(first, second.key, less, false)
+	leftResult := difference(left, second.left, less)
+	rightResult := difference(right, second.right, less)
+	left.decref()
+	mid.decref()
+	right.decref()
+
+	result := merge(leftResult, rightResult)
+	leftResult.decref()
+	rightResult.decref()
+	return result
+}
+
+// forEachUntil visits the subtree rooted at node in ascending key order,
+// stopping early if f returns false. It returns false if iteration was
+// stopped early.
+func (node *mapNode) forEachUntil(f func(key, value interface{}) bool) bool {
+	if node == nil {
+		return true
+	}
+	if !node.left.forEachUntil(f) {
+		return false
+	}
+	if !f(node.key, node.value.value) {
+		return false
+	}
+	return node.right.forEachUntil(f)
+}
+
+// RangeBounded calls f sequentially in ascending key order for all entries
+// with a key in [lo, hi), stopping early if f returns false. Unlike Range,
+// it slices out the requested subrange rather than walking (and filtering)
+// the whole map.
+func (pm *PMap[K, V]) RangeBounded(lo, hi K, f func(key K, value V) bool) {
+	left, mid, right := split(pm.root, lo, pm.less, false)
+	left.decref()
+	fromLo := merge(mid, right)
+	mid.decref()
+	right.decref()
+
+	bounded, mid2, upper := split(fromLo, hi, pm.less, false)
+	fromLo.decref()
+	mid2.decref()
+	upper.decref()
+
+	bounded.forEachUntil(func(key, value interface{}) bool {
+		return f(key.(K), value.(V))
+	})
+	bounded.decref()
+}
+
+// Cursor is a pull-style iterator over a PMap, useful for streaming a
+// subrange without allocating a slice of results up front the way Range
+// would. A Cursor holds a reference on the map's tree as of when it was
+// created; it must be Closed once no longer needed.
+type Cursor[K Orderable[K], V any] struct {
+	root    *mapNode
+	less    func(a, b interface{}) bool
+	stack   []*mapNode
+	history []*mapNode
+	pos     int
+}
+
+// Cursor returns a new Cursor positioned before the first entry of the map.
+func (pm *PMap[K, V]) Cursor() *Cursor[K, V] {
+	c := &Cursor[K, V]{
+		root: pm.root.incref(),
+		less: pm.less,
+		pos:  -1,
+	}
+	c.stack = pushLeft(nil, c.root)
+	return c
+}
+
+// pushLeft pushes node and its entire left spine onto stack, returning the
+// extended stack.
+func pushLeft(stack []*mapNode, node *mapNode) []*mapNode {
+	for node != nil {
+		stack = append(stack, node)
+		node = node.left
+	}
+	return stack
+}
+
+// Seek repositions the cursor so that the next call to Next returns the
+// smallest key greater than or equal to key.
+func (c *Cursor[K, V]) Seek(key K) {
+	c.stack = c.stack[:0]
+	c.history = c.history[:0]
+	c.pos = -1
+
+	node := c.root
+	for node != nil {
+		k := node.key.(K)
+		if key.Less(k) {
+			c.stack = append(c.stack, node)
+			node = node.left
+		} else if k.Less(key) {
+			node = node.right
+		} else {
+			c.stack = append(c.stack, node)
+			break
+		}
+	}
+}
+
+// Next advances the cursor and returns the next key/value pair in ascending
+// order. ok is false once the cursor is exhausted.
+func (c *Cursor[K, V]) Next() (k K, v V, ok bool) {
+	if c.pos+1 < len(c.history) {
+		c.pos++
+		node := c.history[c.pos]
+		return node.key.(K), node.value.value.(V), true
+	}
+	if len(c.stack) == 0 {
+		return k, v, false
+	}
+	node := c.stack[len(c.stack)-1]
+	c.stack = c.stack[:len(c.stack)-1]
+	c.stack = pushLeft(c.stack, node.right)
+	c.history = append(c.history, node)
+	c.pos = len(c.history) - 1
+	return node.key.(K), node.value.value.(V), true
+}
+
+// Prev moves the cursor back and returns the previous key/value pair
+// returned by Next. ok is false if there is no earlier entry, in which case
+// the cursor's position is left unchanged so a subsequent Next continues to
+// advance rather than replaying an entry already returned.
+func (c *Cursor[K, V]) Prev() (k K, v V, ok bool) {
+	if c.pos <= 0 {
+		return k, v, false
+	}
+	c.pos--
+	node := c.history[c.pos]
+	return node.key.(K), node.value.value.(V), true
+}
+
+// Close releases the Cursor's reference on the map's tree. The Cursor must
+// not be used again after Close.
+func (c *Cursor[K, V]) Close() {
+	c.root.decref()
+	c.root = nil
+	c.stack = nil
+	c.history = nil
+}
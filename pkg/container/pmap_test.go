@@ -0,0 +1,230 @@
+// Copyright Authors of Cilium
+// SPDX-License-Identifier: Apache-2.0
+
+package container
+
+import "testing"
+
+type intKey int
+
+func (a intKey) Less(b intKey) bool { return a < b }
+
+func newTestMap(values ...int) *PMap[intKey, int] {
+	m := NewPMap[intKey, int]()
+	for _, v := range values {
+		m.Set(intKey(v), v)
+	}
+	return m
+}
+
+func TestPMapIntersect(t *testing.T) {
+	a := newTestMap(1, 2, 3, 4)
+	defer a.Destroy()
+	b := newTestMap(3, 4, 5, 6)
+	defer b.Destroy()
+
+	got := a.Intersect(b)
+	defer got.Destroy()
+
+	want := map[intKey]int{3: 3, 4: 4}
+	if got.Len() != len(want) {
+		t.Fatalf("Len() = %d, want %d", got.Len(), len(want))
+	}
+	for k, v := range want {
+		gotV, ok := got.Get(k)
+		if !ok || gotV != v {
+			t.Errorf("Get(%v) = %v, %v, want %v, true", k, gotV, ok, v)
+		}
+	}
+}
+
+func TestPMapIntersectPrefersFirstOnValueConflict(t *testing.T) {
+	a := NewPMap[intKey, int]()
+	defer a.Destroy()
+	b := NewPMap[intKey, int]()
+	defer b.Destroy()
+
+	// Insert enough shared keys that, given the treap's per-insertion
+	// random weights, some of them are very likely to rebalance with b's
+	// node outweighing a's and some with a's outweighing b's -- exercising
+	// both branches of intersect's internal swap.
+	const n = 64
+	for k := 0; k < n; k++ {
+		a.Set(intKey(k), k)        // a's value for key k is k.
+		b.Set(intKey(k), k+100000) // b's value for key k is distinguishable.
+	}
+
+	got := a.Intersect(b)
+	defer got.Destroy()
+
+	if got.Len() != n {
+		t.Fatalf("Len() = %d, want %d", got.Len(), n)
+	}
+	for k := 0; k < n; k++ {
+		v, ok := got.Get(intKey(k))
+		if !ok {
+			t.Fatalf("Get(%d): missing, want present", k)
+		}
+		if v != k {
+			t.Errorf("Get(%d) = %d, want %d (value from the receiver, not the argument)", k, v, k)
+		}
+	}
+}
+
+func TestPMapDifference(t *testing.T) {
+	a := newTestMap(1, 2, 3, 4)
+	defer a.Destroy()
+	b := newTestMap(3, 4, 5, 6)
+	defer b.Destroy()
+
+	got := a.Difference(b)
+	defer got.Destroy()
+
+	want := map[intKey]int{1: 1, 2: 2}
+	if got.Len() != len(want) {
+		t.Fatalf("Len() = %d, want %d", got.Len(), len(want))
+	}
+	for k, v := range want {
+		gotV, ok := got.Get(k)
+		if !ok || gotV != v {
+			t.Errorf("Get(%v) = %v, %v, want %v, true", k, gotV, ok, v)
+		}
+	}
+	if _, ok := got.Get(3); ok {
+		t.Errorf("Get(3) should not be present in the difference")
+	}
+}
+
+func TestPMapLenMinMax(t *testing.T) {
+	m := NewPMap[intKey, int]()
+	defer m.Destroy()
+
+	if m.Len() != 0 {
+		t.Fatalf("Len() on empty map = %d, want 0", m.Len())
+	}
+	if _, _, ok := m.Min(); ok {
+		t.Errorf("Min() on empty map: ok = true, want false")
+	}
+	if _, _, ok := m.Max(); ok {
+		t.Errorf("Max() on empty map: ok = true, want false")
+	}
+
+	for _, v := range []int{5, 1, 9, 3} {
+		m.Set(intKey(v), v)
+	}
+
+	if m.Len() != 4 {
+		t.Fatalf("Len() = %d, want 4", m.Len())
+	}
+	if k, v, ok := m.Min(); !ok || k != 1 || v != 1 {
+		t.Errorf("Min() = %v, %v, %v, want 1, 1, true", k, v, ok)
+	}
+	if k, v, ok := m.Max(); !ok || k != 9 || v != 9 {
+		t.Errorf("Max() = %v, %v, %v, want 9, 9, true", k, v, ok)
+	}
+}
+
+func TestPMapRangeBounded(t *testing.T) {
+	m := newTestMap(1, 2, 3, 4, 5, 6)
+	defer m.Destroy()
+
+	var got []intKey
+	m.RangeBounded(2, 5, func(k intKey, v int) bool {
+		got = append(got, k)
+		return true
+	})
+
+	want := []intKey{2, 3, 4}
+	if len(got) != len(want) {
+		t.Fatalf("RangeBounded visited %v, want %v", got, want)
+	}
+	for i, k := range want {
+		if got[i] != k {
+			t.Errorf("RangeBounded[%d] = %v, want %v", i, got[i], k)
+		}
+	}
+}
+
+func TestPMapRangeBoundedStopsEarly(t *testing.T) {
+	m := newTestMap(1, 2, 3, 4, 5)
+	defer m.Destroy()
+
+	var got []intKey
+	m.RangeBounded(1, 5, func(k intKey, v int) bool {
+		got = append(got, k)
+		return k < 3
+	})
+
+	want := []intKey{1, 2, 3}
+	if len(got) != len(want) {
+		t.Fatalf("RangeBounded visited %v, want %v", got, want)
+	}
+}
+
+func TestPMapCursor(t *testing.T) {
+	m := newTestMap(1, 2, 3)
+	defer m.Destroy()
+
+	c := m.Cursor()
+	defer c.Close()
+
+	k, v, ok := c.Next()
+	if !ok || k != 1 || v != 1 {
+		t.Fatalf("Next() = %v, %v, %v, want 1, 1, true", k, v, ok)
+	}
+
+	// A Prev at the start of iteration must fail without moving the
+	// cursor, so the following Next advances instead of replaying 1.
+	if _, _, ok := c.Prev(); ok {
+		t.Fatalf("Prev() at start: ok = true, want false")
+	}
+
+	k, v, ok = c.Next()
+	if !ok || k != 2 || v != 2 {
+		t.Fatalf("Next() after failed Prev() = %v, %v, %v, want 2, 2, true", k, v, ok)
+	}
+
+	k, v, ok = c.Prev()
+	if !ok || k != 1 || v != 1 {
+		t.Fatalf("Prev() = %v, %v, %v, want 1, 1, true", k, v, ok)
+	}
+
+	k, v, ok = c.Next()
+	if !ok || k != 2 || v != 2 {
+		t.Fatalf("Next() after Prev() = %v, %v, %v, want 2, 2, true", k, v, ok)
+	}
+
+	k, v, ok = c.Next()
+	if !ok || k != 3 || v != 3 {
+		t.Fatalf("Next() = %v, %v, %v, want 3, 3, true", k, v, ok)
+	}
+
+	if _, _, ok := c.Next(); ok {
+		t.Fatalf("Next() past the end: ok = true, want false")
+	}
+}
+
+func TestPMapCursorSeek(t *testing.T) {
+	m := newTestMap(1, 3, 5, 7)
+	defer m.Destroy()
+
+	c := m.Cursor()
+	defer c.Close()
+
+	c.Seek(4)
+	k, v, ok := c.Next()
+	if !ok || k != 5 || v != 5 {
+		t.Fatalf("Next() after Seek(4) = %v, %v, %v, want 5, 5, true", k, v, ok)
+	}
+
+	c.Seek(3)
+	k, v, ok = c.Next()
+	if !ok || k != 3 || v != 3 {
+		t.Fatalf("Next() after Seek(3) = %v, %v, %v, want 3, 3, true", k, v, ok)
+	}
+
+	c.Seek(8)
+	if _, _, ok := c.Next(); ok {
+		t.Fatalf("Next() after Seek(8) past the end: ok = true, want false")
+	}
+}
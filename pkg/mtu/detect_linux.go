@@ -0,0 +1,41 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Cilium
+
+package mtu
+
+import (
+	"fmt"
+
+	"github.com/vishvananda/netlink"
+)
+
+// autoDetect returns the MTU of device. If device is empty, it returns the
+// MTU used on the path to a default route on the node instead, so that
+// single-homed nodes (and callers that don't care about a specific device)
+// keep working without naming one.
+func autoDetect(device string) (int, error) {
+	if device != "" {
+		link, err := netlink.LinkByName(device)
+		if err != nil {
+			return 0, fmt.Errorf("unable to find link %q: %w", device, err)
+		}
+		return link.Attrs().MTU, nil
+	}
+
+	routes, err := netlink.RouteList(nil, netlink.FAMILY_V4)
+	if err != nil {
+		return 0, fmt.Errorf("unable to list routes: %w", err)
+	}
+
+	for _, route := range routes {
+		if route.Dst == nil {
+			link, err := netlink.LinkByIndex(route.LinkIndex)
+			if err != nil {
+				return 0, fmt.Errorf("unable to find link for route: %w", err)
+			}
+			return link.Attrs().MTU, nil
+		}
+	}
+
+	return 0, fmt.Errorf("unable to detect MTU: no default route found")
+}
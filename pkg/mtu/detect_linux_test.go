@@ -5,6 +5,7 @@ package mtu
 
 import (
 	"fmt"
+	"net"
 	"os/exec"
 
 	"github.com/cilium/cilium/pkg/testutils"
@@ -12,15 +13,48 @@ import (
 	. "gopkg.in/check.v1"
 )
 
+// multiHomingTestDevices returns the devices the MTU auto detection test
+// should probe: every non-loopback network interface present on the test
+// host, standing in for a multi-homing configuration's device list. An
+// empty device name means "probe the default route", the pre-multi-homing
+// behavior.
+func multiHomingTestDevices(c *C) []string {
+	ifaces, err := net.Interfaces()
+	c.Assert(err, IsNil)
+
+	var devices []string
+	for _, iface := range ifaces {
+		if iface.Flags&net.FlagLoopback != 0 {
+			continue
+		}
+		devices = append(devices, iface.Name)
+	}
+	if len(devices) == 0 {
+		return []string{""}
+	}
+	return devices
+}
+
 func (m *MTUSuite) TestAutoDetect(c *C) {
 	testutils.PrivilegedCheck(c)
 
-	mtu, err := autoDetect()
+	for _, device := range multiHomingTestDevices(c) {
+		mtu, err := autoDetectForDevice(device, c)
+		c.Assert(err, IsNil)
+		c.Assert(mtu, Not(Equals), 0)
+	}
+}
+
+// autoDetectForDevice runs autoDetect for a single device, retrying once and
+// dumping routing/link state for debugging if both attempts fail. An empty
+// device probes the default route, matching the pre-multi-homing behavior.
+func autoDetectForDevice(device string, c *C) (int, error) {
+	mtu, err := autoDetect(device)
 	if err != nil {
-		fmt.Printf("MTU auto detection failed: %s, retrying...\n", err)
-		mtu, err = autoDetect()
+		fmt.Printf("MTU auto detection for device %q failed: %s, retrying...\n", device, err)
+		mtu, err = autoDetect(device)
 	} else {
-		fmt.Printf("MTU auto detection worked\n")
+		fmt.Printf("MTU auto detection for device %q worked\n", device)
 	}
 	if err != nil {
 		// Execute "ip route show all"
@@ -57,14 +91,13 @@ func (m *MTUSuite) TestAutoDetect(c *C) {
 		c.Assert(err, IsNil)
 		fmt.Println("ip route get 1.1.1.1 output:")
 		fmt.Println(string(getOutput))
-		mtu, err = autoDetect()
+		mtu, err = autoDetect(device)
 		if err != nil {
-			fmt.Printf("MTU auto detection failed: %s, retrying...\n", err)
-			mtu, err = autoDetect()
+			fmt.Printf("MTU auto detection for device %q failed: %s, retrying...\n", device, err)
+			mtu, err = autoDetect(device)
 		} else {
-			fmt.Printf("MTU auto detection worked\n")
+			fmt.Printf("MTU auto detection for device %q worked\n", device)
 		}
 	}
-	c.Assert(err, IsNil)
-	c.Assert(mtu, Not(Equals), 0)
+	return mtu, err
 }
@@ -0,0 +1,38 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Cilium
+
+package multihoming
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// PatchHandler serves PATCH /config/multi-homing: it decodes the request
+// body into a Delta and applies it via Manager.
+type PatchHandler struct {
+	Manager *Manager
+}
+
+func (h *PatchHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var delta Delta
+	if err := json.NewDecoder(r.Body).Decode(&delta); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := h.Manager.Apply(delta); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(h.Manager.Devices()); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// RegisterRoutes mounts the multi-homing config endpoint on mux.
+func RegisterRoutes(mux *http.ServeMux, manager *Manager) {
+	mux.Handle("PATCH /config/multi-homing", &PatchHandler{Manager: manager})
+}
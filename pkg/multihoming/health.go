@@ -0,0 +1,162 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Cilium
+
+package multihoming
+
+import (
+	"net"
+	"sync"
+	"time"
+
+	"github.com/vishvananda/netlink"
+
+	"github.com/cilium/cilium/api/v1/models"
+)
+
+// FlapWindow is the sliding window used to rate-limit per-device link
+// flaps.
+const FlapWindow = time.Minute
+
+// HealthMonitor watches netlink link state for a set of devices and
+// reports each one's status: its current MTU, and whether it is up, down,
+// or degraded (MTU below mtuFloor, or flapping more than
+// maxFlapsPerMinute times within FlapWindow).
+type HealthMonitor struct {
+	mtuFloor          int
+	maxFlapsPerMinute int
+
+	mu      sync.Mutex
+	devices map[string]*deviceHealth
+}
+
+type deviceHealth struct {
+	state     string
+	mtu       int
+	flapTimes []time.Time
+	lastFlap  time.Time
+	up        bool
+}
+
+// NewHealthMonitor returns a HealthMonitor for the given devices. A device
+// is considered degraded once its MTU drops below mtuFloor, or once it
+// flaps more than maxFlapsPerMinute times within FlapWindow.
+func NewHealthMonitor(devices []string, mtuFloor, maxFlapsPerMinute int) *HealthMonitor {
+	h := &HealthMonitor{
+		mtuFloor:          mtuFloor,
+		maxFlapsPerMinute: maxFlapsPerMinute,
+		devices:           make(map[string]*deviceHealth, len(devices)),
+	}
+	for _, d := range devices {
+		h.devices[d] = &deviceHealth{state: models.MultiHomingDeviceStatusStateDown}
+	}
+	return h
+}
+
+// AddDevice begins tracking name's link health, in the "down" state until
+// the next netlink update is observed. It is a no-op if name is already
+// tracked. Manager calls this when a device is hot-added via Apply so the
+// monitored set stays in sync with the live multi-homing device set.
+func (h *HealthMonitor) AddDevice(name string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if _, ok := h.devices[name]; ok {
+		return
+	}
+	h.devices[name] = &deviceHealth{state: models.MultiHomingDeviceStatusStateDown}
+}
+
+// RemoveDevice stops tracking name's link health. Manager calls this when a
+// device is hot-removed via Apply.
+func (h *HealthMonitor) RemoveDevice(name string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.devices, name)
+}
+
+// Run watches RTM_NEWLINK/RTM_DELLINK updates until stop is closed,
+// updating device health as links come up, go down, or flap. It returns
+// once stop is closed, or immediately if subscribing to netlink fails.
+func (h *HealthMonitor) Run(stop <-chan struct{}) error {
+	updates := make(chan netlink.LinkUpdate)
+	done := make(chan struct{})
+	defer close(done)
+
+	if err := netlink.LinkSubscribe(updates, done); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-stop:
+			return nil
+		case update := <-updates:
+			h.handleUpdate(update)
+		}
+	}
+}
+
+func (h *HealthMonitor) handleUpdate(update netlink.LinkUpdate) {
+	name := update.Attrs().Name
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	dev, ok := h.devices[name]
+	if !ok {
+		// Not one of our configured multi-homing devices.
+		return
+	}
+
+	now := time.Now()
+	mtu := update.Attrs().MTU
+	up := update.Attrs().Flags&net.FlagUp != 0
+	state := models.MultiHomingDeviceStatusStateDown
+	if up {
+		state = models.MultiHomingDeviceStatusStateUp
+	}
+
+	dev.flapTimes = pruneFlaps(dev.flapTimes, now)
+	if dev.up != up {
+		dev.flapTimes = append(dev.flapTimes, now)
+		dev.lastFlap = now
+	}
+	dev.up = up
+	dev.mtu = mtu
+	dev.state = state
+
+	if len(dev.flapTimes) > h.maxFlapsPerMinute || (mtu > 0 && mtu < h.mtuFloor) {
+		dev.state = models.MultiHomingDeviceStatusStateDegraded
+	}
+}
+
+// pruneFlaps drops flap timestamps older than FlapWindow.
+func pruneFlaps(times []time.Time, now time.Time) []time.Time {
+	cutoff := now.Add(-FlapWindow)
+	i := 0
+	for ; i < len(times); i++ {
+		if times[i].After(cutoff) {
+			break
+		}
+	}
+	return times[i:]
+}
+
+// Status returns the current DeviceStatus for every monitored device.
+func (h *HealthMonitor) Status() []*models.MultiHomingDeviceStatus {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	statuses := make([]*models.MultiHomingDeviceStatus, 0, len(h.devices))
+	for name, dev := range h.devices {
+		status := &models.MultiHomingDeviceStatus{
+			Name:  name,
+			State: dev.state,
+			MTU:   int64(dev.mtu),
+		}
+		if !dev.lastFlap.IsZero() {
+			status.LastLinkFlap = dev.lastFlap.Unix()
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
@@ -0,0 +1,114 @@
+// Copyright Authors of Cilium
+// SPDX-License-Identifier: Apache-2.0
+
+package multihoming
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/vishvananda/netlink"
+
+	"github.com/cilium/cilium/api/v1/models"
+)
+
+func linkUpdate(name string, mtu int, up bool) netlink.LinkUpdate {
+	var flags net.Flags
+	if up {
+		flags |= net.FlagUp
+	}
+	return netlink.LinkUpdate{
+		Link: &netlink.Dummy{
+			LinkAttrs: netlink.LinkAttrs{
+				Name:  name,
+				MTU:   mtu,
+				Flags: flags,
+			},
+		},
+	}
+}
+
+func TestHealthMonitorIgnoresUnknownDevices(t *testing.T) {
+	h := NewHealthMonitor([]string{"eth0"}, 1280, 3)
+	h.handleUpdate(linkUpdate("eth9", 1500, true))
+
+	if _, ok := h.devices["eth9"]; ok {
+		t.Fatalf("handleUpdate tracked eth9, which was never configured")
+	}
+}
+
+func TestHealthMonitorTracksUpAndDown(t *testing.T) {
+	h := NewHealthMonitor([]string{"eth0"}, 1280, 3)
+
+	h.handleUpdate(linkUpdate("eth0", 1500, true))
+	statuses := h.Status()
+	if len(statuses) != 1 || statuses[0].State != models.MultiHomingDeviceStatusStateUp || statuses[0].MTU != 1500 {
+		t.Fatalf("Status() = %+v, want eth0 up at MTU 1500", statuses[0])
+	}
+
+	h.handleUpdate(linkUpdate("eth0", 1500, false))
+	statuses = h.Status()
+	if statuses[0].State != models.MultiHomingDeviceStatusStateDown {
+		t.Fatalf("Status().State = %s, want down", statuses[0].State)
+	}
+}
+
+func TestHealthMonitorDegradesBelowMTUFloor(t *testing.T) {
+	h := NewHealthMonitor([]string{"eth0"}, 1280, 3)
+
+	h.handleUpdate(linkUpdate("eth0", 1200, true))
+	statuses := h.Status()
+	if statuses[0].State != models.MultiHomingDeviceStatusStateDegraded {
+		t.Fatalf("Status().State = %s, want degraded for MTU below floor", statuses[0].State)
+	}
+}
+
+func TestHealthMonitorDegradesOnExcessiveFlapping(t *testing.T) {
+	h := NewHealthMonitor([]string{"eth0"}, 1280, 3)
+
+	up := true
+	for i := 0; i < 5; i++ {
+		h.handleUpdate(linkUpdate("eth0", 1500, up))
+		up = !up
+	}
+
+	statuses := h.Status()
+	if statuses[0].State != models.MultiHomingDeviceStatusStateDegraded {
+		t.Fatalf("Status().State = %s, want degraded after repeated flapping", statuses[0].State)
+	}
+	if statuses[0].LastLinkFlap == 0 {
+		t.Errorf("Status().LastLinkFlap = 0, want a recorded flap timestamp")
+	}
+}
+
+func TestHealthMonitorPruneFlapsDropsOldEntries(t *testing.T) {
+	now := time.Now()
+	old := now.Add(-2 * FlapWindow)
+	recent := now.Add(-time.Second)
+
+	got := pruneFlaps([]time.Time{old, recent}, now)
+	if len(got) != 1 || !got[0].Equal(recent) {
+		t.Fatalf("pruneFlaps() = %v, want only the recent entry", got)
+	}
+}
+
+func TestHealthMonitorAddRemoveDevice(t *testing.T) {
+	h := NewHealthMonitor(nil, 1280, 3)
+
+	h.AddDevice("eth0")
+	if _, ok := h.devices["eth0"]; !ok {
+		t.Fatalf("AddDevice did not register eth0")
+	}
+
+	h.handleUpdate(linkUpdate("eth0", 1500, true))
+	statuses := h.Status()
+	if len(statuses) != 1 || statuses[0].State != models.MultiHomingDeviceStatusStateUp {
+		t.Fatalf("Status() = %+v, want eth0 up after AddDevice", statuses)
+	}
+
+	h.RemoveDevice("eth0")
+	if len(h.Status()) != 0 {
+		t.Fatalf("Status() = %v, want empty after RemoveDevice", h.Status())
+	}
+}
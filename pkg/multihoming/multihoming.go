@@ -0,0 +1,110 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Cilium
+
+// Package multihoming implements runtime hot-reconfiguration of the set of
+// devices cilium-agent operates in multi-homing mode, together with a
+// background health probe that tracks per-device link state. The device set
+// can be reconfigured over HTTP; the health status is not served there.
+package multihoming
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Attacher re-runs the datapath wiring a device needs when it enters or
+// leaves multi-homing mode: BPF program attachment and the corresponding
+// datapath map updates. The daemon supplies the concrete implementation;
+// Manager only decides when to call it.
+type Attacher interface {
+	AttachDevice(name string) error
+	DetachDevice(name string) error
+}
+
+// HealthTracker lets Manager keep a HealthMonitor's watched device set in
+// sync with the live multi-homing device set as devices are hot-added and
+// hot-removed. *HealthMonitor satisfies this interface.
+type HealthTracker interface {
+	AddDevice(name string)
+	RemoveDevice(name string)
+}
+
+// Manager tracks the set of devices currently in multi-homing mode and
+// applies add/remove deltas without requiring an agent restart.
+type Manager struct {
+	attacher Attacher
+	health   HealthTracker
+
+	mu      sync.Mutex
+	devices map[string]struct{}
+}
+
+// NewManager returns a Manager seeded with the given startup device list.
+// health may be nil if no health probe is in use.
+func NewManager(attacher Attacher, health HealthTracker, devices []string) *Manager {
+	m := &Manager{
+		attacher: attacher,
+		health:   health,
+		devices:  make(map[string]struct{}, len(devices)),
+	}
+	for _, d := range devices {
+		m.devices[d] = struct{}{}
+	}
+	return m
+}
+
+// Devices returns the current set of multi-homing devices.
+func (m *Manager) Devices() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	devices := make([]string, 0, len(m.devices))
+	for d := range m.devices {
+		devices = append(devices, d)
+	}
+	return devices
+}
+
+// Delta is the add/remove payload of a PATCH /config/multi-homing request.
+type Delta struct {
+	Add    []string
+	Remove []string
+}
+
+// Apply attaches every device in delta.Add and detaches every device in
+// delta.Remove, re-running BPF program attachment and datapath map updates
+// for each one in turn. Devices already in the requested state are left
+// untouched. Apply stops at the first error; devices already
+// attached/detached before the error occurred remain so, matching how
+// startup device configuration is not rolled back on a later failure.
+func (m *Manager) Apply(delta Delta) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, d := range delta.Add {
+		if _, ok := m.devices[d]; ok {
+			continue
+		}
+		if err := m.attacher.AttachDevice(d); err != nil {
+			return fmt.Errorf("attaching device %q: %w", d, err)
+		}
+		m.devices[d] = struct{}{}
+		if m.health != nil {
+			m.health.AddDevice(d)
+		}
+	}
+
+	for _, d := range delta.Remove {
+		if _, ok := m.devices[d]; !ok {
+			continue
+		}
+		if err := m.attacher.DetachDevice(d); err != nil {
+			return fmt.Errorf("detaching device %q: %w", d, err)
+		}
+		delete(m.devices, d)
+		if m.health != nil {
+			m.health.RemoveDevice(d)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,143 @@
+// Copyright Authors of Cilium
+// SPDX-License-Identifier: Apache-2.0
+
+package multihoming
+
+import (
+	"errors"
+	"testing"
+)
+
+type fakeAttacher struct {
+	attached map[string]bool
+	detached map[string]bool
+	failOn   string
+}
+
+func newFakeAttacher() *fakeAttacher {
+	return &fakeAttacher{
+		attached: make(map[string]bool),
+		detached: make(map[string]bool),
+	}
+}
+
+func (f *fakeAttacher) AttachDevice(name string) error {
+	if name == f.failOn {
+		return errors.New("attach failed")
+	}
+	f.attached[name] = true
+	return nil
+}
+
+func (f *fakeAttacher) DetachDevice(name string) error {
+	if name == f.failOn {
+		return errors.New("detach failed")
+	}
+	f.detached[name] = true
+	return nil
+}
+
+type fakeHealthTracker struct {
+	added   []string
+	removed []string
+}
+
+func (f *fakeHealthTracker) AddDevice(name string)    { f.added = append(f.added, name) }
+func (f *fakeHealthTracker) RemoveDevice(name string) { f.removed = append(f.removed, name) }
+
+func TestManagerApplyAdd(t *testing.T) {
+	attacher := newFakeAttacher()
+	health := &fakeHealthTracker{}
+	m := NewManager(attacher, health, nil)
+
+	if err := m.Apply(Delta{Add: []string{"eth0", "eth1"}}); err != nil {
+		t.Fatalf("Apply() = %v, want nil", err)
+	}
+
+	if !attacher.attached["eth0"] || !attacher.attached["eth1"] {
+		t.Errorf("attacher.attached = %v, want eth0 and eth1 attached", attacher.attached)
+	}
+	if len(health.added) != 2 {
+		t.Errorf("health.added = %v, want 2 devices added", health.added)
+	}
+
+	devices := m.Devices()
+	if len(devices) != 2 {
+		t.Fatalf("Devices() = %v, want 2 devices", devices)
+	}
+}
+
+func TestManagerApplyRemove(t *testing.T) {
+	attacher := newFakeAttacher()
+	health := &fakeHealthTracker{}
+	m := NewManager(attacher, health, []string{"eth0", "eth1"})
+
+	if err := m.Apply(Delta{Remove: []string{"eth0"}}); err != nil {
+		t.Fatalf("Apply() = %v, want nil", err)
+	}
+
+	if !attacher.detached["eth0"] {
+		t.Errorf("attacher.detached = %v, want eth0 detached", attacher.detached)
+	}
+	if len(health.removed) != 1 || health.removed[0] != "eth0" {
+		t.Errorf("health.removed = %v, want [eth0]", health.removed)
+	}
+
+	devices := m.Devices()
+	if len(devices) != 1 || devices[0] != "eth1" {
+		t.Fatalf("Devices() = %v, want [eth1]", devices)
+	}
+}
+
+func TestManagerApplyIsNoOpForAlreadyAppliedDevices(t *testing.T) {
+	attacher := newFakeAttacher()
+	m := NewManager(attacher, nil, []string{"eth0"})
+
+	// eth0 is already attached, and eth1 was never attached: re-adding
+	// eth0 and removing eth1 must not call into the attacher at all.
+	if err := m.Apply(Delta{Add: []string{"eth0"}, Remove: []string{"eth1"}}); err != nil {
+		t.Fatalf("Apply() = %v, want nil", err)
+	}
+	if len(attacher.attached) != 0 || len(attacher.detached) != 0 {
+		t.Errorf("attacher.attached = %v, detached = %v, want no calls", attacher.attached, attacher.detached)
+	}
+}
+
+func TestManagerApplyAttachError(t *testing.T) {
+	attacher := newFakeAttacher()
+	attacher.failOn = "eth1"
+	health := &fakeHealthTracker{}
+	m := NewManager(attacher, health, nil)
+
+	err := m.Apply(Delta{Add: []string{"eth0", "eth1"}})
+	if err == nil {
+		t.Fatal("Apply() = nil, want an error")
+	}
+
+	// eth0 was processed before the failing eth1 and should remain applied.
+	if !attacher.attached["eth0"] {
+		t.Errorf("attacher.attached = %v, want eth0 attached despite the later failure", attacher.attached)
+	}
+	devices := m.Devices()
+	if len(devices) != 1 || devices[0] != "eth0" {
+		t.Errorf("Devices() = %v, want [eth0]", devices)
+	}
+	if len(health.added) != 1 || health.added[0] != "eth0" {
+		t.Errorf("health.added = %v, want [eth0]", health.added)
+	}
+}
+
+func TestManagerApplyDetachError(t *testing.T) {
+	attacher := newFakeAttacher()
+	attacher.failOn = "eth0"
+	m := NewManager(attacher, nil, []string{"eth0"})
+
+	if err := m.Apply(Delta{Remove: []string{"eth0"}}); err == nil {
+		t.Fatal("Apply() = nil, want an error")
+	}
+
+	devices := m.Devices()
+	if len(devices) != 1 || devices[0] != "eth0" {
+		t.Errorf("Devices() = %v, want eth0 to remain since detach failed", devices)
+	}
+}
@@ -0,0 +1,59 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Cilium
+
+package wireguard
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"golang.zx2c4.com/wireguard/wgctrl"
+
+	"github.com/cilium/cilium/api/v1/models"
+)
+
+// StatusHandler serves the status of a single Wireguard interface over
+// HTTP: GET /wireguard/{iface}[?with-traffic=true]. with-traffic adds the
+// per-peer traffic counters and handshake ages to the response and also
+// triggers a Prometheus metrics refresh for the interface. The device is
+// read from wgctrl with one call on every request, with or without
+// with-traffic.
+type StatusHandler struct {
+	// Client is used to read the live Wireguard device state.
+	Client *wgctrl.Client
+}
+
+func (h *StatusHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("iface")
+	if name == "" {
+		http.Error(w, "missing interface name", http.StatusBadRequest)
+		return
+	}
+
+	// An invalid with-traffic value is treated as false rather than
+	// rejected, matching the query parameter's role as an optional hint.
+	withTraffic, _ := strconv.ParseBool(r.URL.Query().Get("with-traffic"))
+
+	dev, err := h.Client.Device(name)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	iface := &models.WireguardInterface{}
+	FillInterfaceStatus(iface, dev, withTraffic)
+	if withTraffic {
+		UpdateMetrics(dev)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(iface); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// RegisterRoutes mounts the Wireguard status endpoint on mux.
+func RegisterRoutes(mux *http.ServeMux, client *wgctrl.Client) {
+	mux.Handle("GET /wireguard/{iface}", &StatusHandler{Client: client})
+}
@@ -0,0 +1,71 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Cilium
+
+package wireguard
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
+)
+
+var (
+	peerLabels = []string{"interface", "peer"}
+
+	rxBytesMetric = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "cilium",
+		Subsystem: "wireguard",
+		Name:      "peer_rx_bytes",
+		Help:      "Bytes received from a Wireguard peer",
+	}, peerLabels)
+
+	txBytesMetric = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "cilium",
+		Subsystem: "wireguard",
+		Name:      "peer_tx_bytes",
+		Help:      "Bytes transmitted to a Wireguard peer",
+	}, peerLabels)
+
+	handshakeAgeMetric = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "cilium",
+		Subsystem: "wireguard",
+		Name:      "peer_handshake_age_seconds",
+		Help:      "Seconds since the last successful handshake with a Wireguard peer",
+	}, peerLabels)
+
+	stalePeersMetric = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "cilium",
+		Subsystem: "wireguard",
+		Name:      "stale_peers",
+		Help:      "Number of peers on the interface whose last handshake is older than the rekey threshold (~180s)",
+	}, []string{"interface"})
+)
+
+func init() {
+	prometheus.MustRegister(rxBytesMetric, txBytesMetric, handshakeAgeMetric, stalePeersMetric)
+}
+
+// UpdateMetrics exports dev's per-peer traffic and handshake counters as
+// Prometheus metrics. Callers should invoke it alongside
+// FillInterfaceStatus(..., withTraffic=true), since both read the same
+// wgctrl device state.
+func UpdateMetrics(dev *wgtypes.Device) {
+	now := time.Now()
+	var stale float64
+	for _, p := range dev.Peers {
+		peer := p.PublicKey.String()
+		rxBytesMetric.WithLabelValues(dev.Name, peer).Set(float64(p.ReceiveBytes))
+		txBytesMetric.WithLabelValues(dev.Name, peer).Set(float64(p.TransmitBytes))
+
+		if p.LastHandshakeTime.IsZero() {
+			continue
+		}
+		age := now.Sub(p.LastHandshakeTime)
+		handshakeAgeMetric.WithLabelValues(dev.Name, peer).Set(age.Seconds())
+		if age > RekeyThreshold {
+			stale++
+		}
+	}
+	stalePeersMetric.WithLabelValues(dev.Name).Set(stale)
+}
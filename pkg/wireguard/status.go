@@ -0,0 +1,80 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Cilium
+
+// Package wireguard fills in the WireGuard status reported over the API
+// from the live wgctrl device state, serves it over HTTP, and exports the
+// same per-peer counters as Prometheus metrics for Hubble/Grafana
+// dashboards.
+package wireguard
+
+import (
+	"time"
+
+	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
+
+	"github.com/cilium/cilium/api/v1/models"
+)
+
+// RekeyThreshold is the approximate WireGuard rekey interval. A peer whose
+// last handshake is older than this is considered stale.
+const RekeyThreshold = 180 * time.Second
+
+// FillInterfaceStatus populates iface from dev. Traffic and handshake
+// diagnostics (RxBytes, TxBytes, HandshakeFailures, StalePeerCount, and the
+// per-peer LastHandshakeTimestamp/HandshakeAgeSeconds) are only filled in
+// when withTraffic is true. They are taken from dev, which already carries
+// them, so withTraffic does not change how dev is read from wgctrl.
+func FillInterfaceStatus(iface *models.WireguardInterface, dev *wgtypes.Device, withTraffic bool) {
+	iface.ListenPort = int64(dev.ListenPort)
+	iface.Name = dev.Name
+	iface.PublicKey = dev.PublicKey.String()
+	iface.PeerCount = int64(len(dev.Peers))
+	iface.Peers = make([]*models.WireguardPeer, 0, len(dev.Peers))
+
+	if !withTraffic {
+		for _, p := range dev.Peers {
+			iface.Peers = append(iface.Peers, peerStatus(p))
+		}
+		return
+	}
+
+	now := time.Now()
+	var rxBytes, txBytes, failures, stale int64
+	for _, p := range dev.Peers {
+		peer := peerStatus(p)
+
+		rxBytes += p.ReceiveBytes
+		txBytes += p.TransmitBytes
+
+		if p.LastHandshakeTime.IsZero() {
+			failures++
+		} else {
+			peer.LastHandshakeTimestamp = p.LastHandshakeTime.Unix()
+			age := now.Sub(p.LastHandshakeTime)
+			peer.HandshakeAgeSeconds = int64(age.Seconds())
+			if age > RekeyThreshold {
+				stale++
+			}
+		}
+
+		iface.Peers = append(iface.Peers, peer)
+	}
+
+	iface.RxBytes = rxBytes
+	iface.TxBytes = txBytes
+	iface.HandshakeFailures = failures
+	iface.StalePeerCount = stale
+}
+
+func peerStatus(p wgtypes.Peer) *models.WireguardPeer {
+	peer := &models.WireguardPeer{
+		PublicKey: p.PublicKey.String(),
+	}
+	if p.Endpoint != nil {
+		peer.Endpoint = p.Endpoint.String()
+	}
+	for _, ip := range p.AllowedIPs {
+		peer.AllowedIps = append(peer.AllowedIps, ip.String())
+	}
+	return peer
+}
@@ -4,13 +4,18 @@
 package main
 
 import (
+	"encoding/json"
 	"encoding/xml"
 	"flag"
 	"fmt"
+	"html"
 	"io"
 	"os"
+	"path"
 	"sort"
 	"strings"
+
+	"golang.org/x/tools/cover"
 )
 
 type TestSuites struct {
@@ -62,12 +67,18 @@ type Error struct {
 	Text    string `xml:",chardata"`
 }
 
+// TestCaseResult is a single, flattened row of the report: either a
+// per-testcase result, or (when a suite reports no testcases at all) a
+// suite-level rollup.
 type TestCaseResult struct {
-	TestSuite string
-	TestCase  string
-	ClassName string
-	Status    string
-	Time      float64
+	TestSuite string   `json:"testSuite"`
+	TestCase  string   `json:"testCase,omitempty"`
+	ClassName string   `json:"className,omitempty"`
+	Status    string   `json:"status"`
+	Time      float64  `json:"time"`
+	Message   string   `json:"message,omitempty"`
+	Detail    string   `json:"detail,omitempty"`
+	Coverage  *float64 `json:"coverage,omitempty"`
 }
 
 const (
@@ -77,108 +88,91 @@ const (
 	StatusError   = "Error"
 )
 
-func main() {
-	if len(os.Args) < 2 {
-		fmt.Println("Please provide the path to the input file.")
-		return
-	}
+const (
+	FormatMarkdown = "markdown"
+	FormatHTML     = "html"
+	FormatJSON     = "json"
+)
+
+// inputFiles collects repeated -i flags so results from multiple shards can
+// be merged into a single report.
+type inputFiles []string
+
+func (i *inputFiles) String() string {
+	return strings.Join(*i, ",")
+}
+
+func (i *inputFiles) Set(value string) error {
+	*i = append(*i, value)
+	return nil
+}
 
-	inputFile := flag.String("i", "", "Input file path")
+func main() {
+	var inputs inputFiles
+	flag.Var(&inputs, "i", "Input file path (can be repeated to merge results across shards)")
 	outputFile := flag.String("o", "", "Output file path")
+	format := flag.String("format", FormatMarkdown, "Output format: markdown, html, or json")
+	coverageFile := flag.String("coverage", "", "Optional Go coverage.out profile used to add a Coverage % column per package")
 	failed := flag.Bool("f", true, "Show failed tests")
 	passed := flag.Bool("p", true, "Show passed tests")
 	skipped := flag.Bool("s", true, "Show skipped tests")
 	errored := flag.Bool("e", true, "Show errored tests")
 	flag.Parse()
 
-	// Open the file
-	file, err := os.Open(*inputFile)
-	if err != nil {
-		fmt.Println("Error opening file:", err)
+	if len(inputs) == 0 {
+		fmt.Println("Please provide the path to at least one input file with -i.")
 		return
 	}
-	defer file.Close()
 
-	// Extract test case results
-	var testCaseResults []TestCaseResult
+	switch *format {
+	case FormatMarkdown, FormatHTML, FormatJSON:
+	default:
+		fmt.Printf("Unknown -format %q, must be one of markdown, html, json\n", *format)
+		return
+	}
 
-	// Create a struct to store the unmarshalled data
-	var testsuites TestSuites
-	// Read and decode the XML from the file
-	err = xml.NewDecoder(file).Decode(&testsuites)
-	if err == nil {
-		for _, suite := range testsuites.Suites {
-			if suite.Name == "" {
-				continue
-			}
-			testSuiteStatus := StatusPass
-			if len(suite.Testcases) == 0 {
-				testSuiteStatus = StatusSkipped
-			}
-			for _, testcase := range suite.Testcases {
-				testCaseStatus := status(testcase)
-				switch testCaseStatus {
-				case StatusPass, StatusSkipped:
-				default:
-					testSuiteStatus = testCaseStatus
-				}
-			}
-			testCaseResults = addTestCase(testCaseResults, suite.Name, testSuiteStatus, suite.Time, passed, skipped, failed, errored)
-		}
-	} else {
-		_, err := file.Seek(0, io.SeekStart)
+	var results []TestCaseResult
+	seen := make(map[string]bool)
+	for _, inputFile := range inputs {
+		fileResults, err := parseFile(inputFile, passed, skipped, failed, errored)
 		if err != nil {
-			// try Jenkins
-			fmt.Println("Error seeking:", err)
-			return
-		}
-		// try Jenkins
-		var tsj TestSuiteJenkins
-		// Read and decode the XML from the file
-		err2 := xml.NewDecoder(file).Decode(&tsj)
-		if err2 != nil {
-			// try Jenkins
-			fmt.Println("Error decoding XML:", err2)
+			fmt.Println(err)
 			return
 		}
-		testsuites = TestSuites{
-			XMLName:  tsj.XMLName,
-			Tests:    tsj.Tests,
-			Failures: tsj.Failures,
-			Suites:   []Testsuite{tsj.Testsuite},
-		}
-		for _, suite := range testsuites.Suites {
-			if suite.Name == "" {
+		for _, result := range fileResults {
+			key := strings.Join([]string{result.TestSuite, result.ClassName, result.TestCase}, "\x00")
+			if seen[key] {
 				continue
 			}
-			for _, testcase := range suite.Testcases {
-				testCaseStatus := status(testcase)
-				testCaseResults = addTestCase(testCaseResults, testcase.Name, testCaseStatus, testcase.Time, passed, skipped, failed, errored)
-			}
+			seen[key] = true
+			results = append(results, result)
 		}
 	}
-	if len(testCaseResults) == 0 {
+
+	if len(results) == 0 {
 		return
 	}
 
-	// Sort test case results
-	sort.Slice(testCaseResults, func(i, j int) bool {
-		if testCaseResults[i].Status != testCaseResults[j].Status {
-			switch testCaseResults[i].Status {
-			case StatusError, StatusFail:
-				switch testCaseResults[j].Status {
-				case StatusError, StatusFail:
-					return strings.Compare(testCaseResults[i].TestSuite, testCaseResults[j].TestSuite) < 0
-				}
-				return true
+	var coverageByPackage map[string]float64
+	if *coverageFile != "" {
+		var err error
+		coverageByPackage, err = parseCoverage(*coverageFile)
+		if err != nil {
+			fmt.Println("Error parsing coverage profile:", err)
+			return
+		}
+		for i, result := range results {
+			if pct, ok := coverageForClassName(coverageByPackage, result.ClassName); ok {
+				results[i].Coverage = &pct
 			}
 		}
-		return strings.Compare(testCaseResults[i].TestSuite, testCaseResults[j].TestSuite) < 0
-	})
+	}
+
+	sortResults(results)
 
 	fout := os.Stdout
-	// Write the table to the output
 	if outputFile != nil && len(*outputFile) != 0 {
+		var err error
 		fout, err = os.Create(*outputFile)
 		if err != nil {
 			fmt.Println("Error creating output file:", err)
@@ -187,41 +181,66 @@ func main() {
 		defer fout.Close()
 	}
 
-	// Generate markdown table
-	_, err = io.WriteString(fout, "| Status | Package | Time (seconds) |\n")
+	var err error
+	switch *format {
+	case FormatHTML:
+		err = writeHTML(fout, results)
+	case FormatJSON:
+		err = writeJSON(fout, results)
+	default:
+		err = writeMarkdown(fout, results)
+	}
 	if err != nil {
 		fmt.Println("Error writing output file:", err)
 		return
 	}
-	_, err = io.WriteString(fout, "|--------|---------|----------------|\n")
+
+	if outputFile != nil && len(*outputFile) != 0 {
+		fmt.Println("Report saved to", *outputFile)
+	}
+}
+
+// parseFile decodes a single JUnit XML file (either the "testsuites" or the
+// single Jenkins "testsuite" shape) into flattened, per-testcase rows.
+func parseFile(inputFile string, passed, skipped, failed, errored *bool) ([]TestCaseResult, error) {
+	file, err := os.Open(inputFile)
 	if err != nil {
-		fmt.Println("Error writing output file:", err)
-		return
+		return nil, fmt.Errorf("error opening file %s: %w", inputFile, err)
 	}
-	for _, result := range testCaseResults {
-		var statusEmoji string
-		switch result.Status {
-		case StatusPass:
-			statusEmoji = ":heavy_check_mark:"
-		case StatusSkipped:
-			statusEmoji = ":white_check_mark:"
-		case StatusFail:
-			statusEmoji = ":x:"
-		case StatusError:
-			statusEmoji = ":warning:"
-		}
-		row := fmt.Sprintf("| %-6s | %-10s | %-14.3f |\n", statusEmoji, result.TestSuite, result.Time)
+	defer file.Close()
 
-		_, err = io.WriteString(fout, row)
-		if err != nil {
-			fmt.Println("Error writing output file:", err)
-			return
+	var results []TestCaseResult
+
+	var testsuites TestSuites
+	err = xml.NewDecoder(file).Decode(&testsuites)
+	if err == nil {
+		for _, suite := range testsuites.Suites {
+			if suite.Name == "" {
+				continue
+			}
+			if len(suite.Testcases) == 0 {
+				results = addTestCase(results, suite.Name, "", "", StatusSkipped, suite.Time, "", "", passed, skipped, failed, errored)
+				continue
+			}
+			for _, testcase := range suite.Testcases {
+				results = addTestCase(results, suite.Name, testcase.Classname, testcase.Name, status(testcase), testcase.Time, message(testcase), detail(testcase), passed, skipped, failed, errored)
+			}
 		}
+		return results, nil
 	}
 
-	if outputFile != nil && len(*outputFile) != 0 {
-		fmt.Println("Markdown table saved to", *outputFile)
+	if _, seekErr := file.Seek(0, io.SeekStart); seekErr != nil {
+		return nil, fmt.Errorf("error seeking in %s: %w", inputFile, seekErr)
+	}
+
+	var tsj TestSuiteJenkins
+	if err := xml.NewDecoder(file).Decode(&tsj); err != nil {
+		return nil, fmt.Errorf("error decoding XML in %s: %w", inputFile, err)
 	}
+	for _, testcase := range tsj.Testcases {
+		results = addTestCase(results, testcase.Name, testcase.Classname, testcase.Name, status(testcase), testcase.Time, message(testcase), detail(testcase), passed, skipped, failed, errored)
+	}
+	return results, nil
 }
 
 func status(testcase Testcase) string {
@@ -239,29 +258,255 @@ func status(testcase Testcase) string {
 	return status
 }
 
-func addTestCase(testCaseResults []TestCaseResult, name, status string, timeElapsed float64, passed, skipped, failed, errored *bool) []TestCaseResult {
-	testCaseResult := TestCaseResult{
-		TestSuite: name,
+func message(testcase Testcase) string {
+	switch {
+	case testcase.Failure != nil:
+		return testcase.Failure.Message
+	case testcase.Error != nil:
+		return testcase.Error.Message
+	}
+	return ""
+}
+
+func detail(testcase Testcase) string {
+	switch {
+	case testcase.Failure != nil:
+		return strings.TrimSpace(testcase.Failure.Text)
+	case testcase.Error != nil:
+		return strings.TrimSpace(testcase.Error.Text)
+	}
+	return ""
+}
+
+func addTestCase(results []TestCaseResult, suite, className, testCase, status string, timeElapsed float64, msg, detail string, passed, skipped, failed, errored *bool) []TestCaseResult {
+	result := TestCaseResult{
+		TestSuite: suite,
+		ClassName: className,
+		TestCase:  testCase,
 		Status:    status,
 		Time:      timeElapsed,
+		Message:   msg,
+		Detail:    detail,
 	}
 	switch status {
 	case StatusPass:
 		if *passed {
-			testCaseResults = append(testCaseResults, testCaseResult)
+			results = append(results, result)
 		}
 	case StatusSkipped:
 		if *skipped {
-			testCaseResults = append(testCaseResults, testCaseResult)
+			results = append(results, result)
 		}
 	case StatusFail:
 		if *failed {
-			testCaseResults = append(testCaseResults, testCaseResult)
+			results = append(results, result)
 		}
 	case StatusError:
 		if *errored {
-			testCaseResults = append(testCaseResults, testCaseResult)
+			results = append(results, result)
+		}
+	}
+	return results
+}
+
+func sortResults(results []TestCaseResult) {
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Status != results[j].Status {
+			switch results[i].Status {
+			case StatusError, StatusFail:
+				switch results[j].Status {
+				case StatusError, StatusFail:
+					return lessRow(results[i], results[j])
+				}
+				return true
+			}
+		}
+		return lessRow(results[i], results[j])
+	})
+}
+
+func lessRow(a, b TestCaseResult) bool {
+	if a.TestSuite != b.TestSuite {
+		return strings.Compare(a.TestSuite, b.TestSuite) < 0
+	}
+	return strings.Compare(a.TestCase, b.TestCase) < 0
+}
+
+// parseCoverage parses a Go coverage.out profile and returns the statement
+// coverage percentage per package import path.
+func parseCoverage(coverageFile string) (map[string]float64, error) {
+	profiles, err := cover.ParseProfiles(coverageFile)
+	if err != nil {
+		return nil, err
+	}
+
+	type counts struct {
+		total, covered int64
+	}
+	byPackage := make(map[string]*counts)
+	for _, profile := range profiles {
+		pkg := path.Dir(profile.FileName)
+		c, ok := byPackage[pkg]
+		if !ok {
+			c = &counts{}
+			byPackage[pkg] = c
+		}
+		for _, block := range profile.Blocks {
+			c.total += int64(block.NumStmt)
+			if block.Count > 0 {
+				c.covered += int64(block.NumStmt)
+			}
+		}
+	}
+
+	result := make(map[string]float64, len(byPackage))
+	for pkg, c := range byPackage {
+		if c.total == 0 {
+			result[pkg] = 0
+			continue
+		}
+		result[pkg] = 100 * float64(c.covered) / float64(c.total)
+	}
+	return result, nil
+}
+
+// coverageForClassName maps a JUnit classname (typically the Go package's
+// import path) to its coverage percentage, falling back to a suffix match
+// since classnames are sometimes reported relative to the module root.
+func coverageForClassName(byPackage map[string]float64, className string) (float64, bool) {
+	if className == "" {
+		return 0, false
+	}
+	if pct, ok := byPackage[className]; ok {
+		return pct, true
+	}
+	for pkg, pct := range byPackage {
+		if strings.HasSuffix(pkg, className) || strings.HasSuffix(className, pkg) {
+			return pct, true
+		}
+	}
+	return 0, false
+}
+
+func statusEmoji(status string) string {
+	switch status {
+	case StatusPass:
+		return ":heavy_check_mark:"
+	case StatusSkipped:
+		return ":white_check_mark:"
+	case StatusFail:
+		return ":x:"
+	case StatusError:
+		return ":warning:"
+	}
+	return ""
+}
+
+func writeMarkdown(w io.Writer, results []TestCaseResult) error {
+	hasCoverage := anyCoverage(results)
+	header := "| Status | Package | Test Case | Time (seconds) |"
+	sep := "|--------|---------|-----------|----------------|"
+	if hasCoverage {
+		header += " Coverage % |"
+		sep += "------------|"
+	}
+	if _, err := io.WriteString(w, header+"\n"+sep+"\n"); err != nil {
+		return err
+	}
+
+	for _, result := range results {
+		testCase := escapeMarkdownCell(testCaseLabel(result))
+		if result.Detail != "" {
+			testCase = fmt.Sprintf("%s<details><summary>%s</summary>\n\n```\n%s\n```\n\n</details>", testCase, escapeMarkdownCell(summaryText(result)), result.Detail)
+		}
+
+		row := fmt.Sprintf("| %s | %s | %s | %.3f |", statusEmoji(result.Status), escapeMarkdownCell(result.TestSuite), testCase, result.Time)
+		if hasCoverage {
+			row += " " + coverageCell(result) + " |"
+		}
+		if _, err := io.WriteString(w, row+"\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func testCaseLabel(result TestCaseResult) string {
+	if result.TestCase == "" {
+		return "*(suite)*"
+	}
+	return result.TestCase
+}
+
+// summaryText is the text shown as the collapsed <details> summary for a
+// failed/errored result: its failure/error message, falling back to the
+// status when the JUnit report didn't carry one.
+func summaryText(result TestCaseResult) string {
+	if result.Message != "" {
+		return result.Message
+	}
+	return result.Status
+}
+
+// escapeMarkdownCell makes s safe to interpolate into a markdown table
+// cell: HTML-escaped (GitHub-flavored markdown renders raw HTML inside
+// table cells) and with table-delimiting "|" characters escaped so the
+// cell can't spill into neighboring columns.
+func escapeMarkdownCell(s string) string {
+	s = html.EscapeString(s)
+	s = strings.ReplaceAll(s, "|", "\\|")
+	s = strings.ReplaceAll(s, "\n", " ")
+	return s
+}
+
+func coverageCell(result TestCaseResult) string {
+	if result.Coverage == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%.1f", *result.Coverage)
+}
+
+func writeHTML(w io.Writer, results []TestCaseResult) error {
+	hasCoverage := anyCoverage(results)
+	var buf strings.Builder
+	buf.WriteString("<table>\n<thead>\n<tr><th>Status</th><th>Package</th><th>Test Case</th><th>Time (seconds)</th>")
+	if hasCoverage {
+		buf.WriteString("<th>Coverage %</th>")
+	}
+	buf.WriteString("</tr>\n</thead>\n<tbody>\n")
+
+	for _, result := range results {
+		buf.WriteString("<tr>")
+		fmt.Fprintf(&buf, "<td>%s</td><td>%s</td>", statusEmoji(result.Status), html.EscapeString(result.TestSuite))
+		if result.Detail != "" {
+			fmt.Fprintf(&buf, "<td>%s<details><summary>%s</summary><pre>%s</pre></details></td>",
+				html.EscapeString(testCaseLabel(result)), html.EscapeString(summaryText(result)), html.EscapeString(result.Detail))
+		} else {
+			fmt.Fprintf(&buf, "<td>%s</td>", html.EscapeString(testCaseLabel(result)))
+		}
+		fmt.Fprintf(&buf, "<td>%.3f</td>", result.Time)
+		if hasCoverage {
+			fmt.Fprintf(&buf, "<td>%s</td>", coverageCell(result))
+		}
+		buf.WriteString("</tr>\n")
+	}
+	buf.WriteString("</tbody>\n</table>\n")
+
+	_, err := io.WriteString(w, buf.String())
+	return err
+}
+
+func writeJSON(w io.Writer, results []TestCaseResult) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(results)
+}
+
+func anyCoverage(results []TestCaseResult) bool {
+	for _, result := range results {
+		if result.Coverage != nil {
+			return true
 		}
 	}
-	return testCaseResults
+	return false
 }